@@ -1,14 +1,20 @@
 package get
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/v2/api"
 	"github.com/cli/cli/v2/internal/gh"
 	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/cli/cli/v2/internal/tableprinter"
 	"github.com/cli/cli/v2/pkg/cmd/variable/shared"
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/cli/cli/v2/pkg/iostreams"
@@ -20,20 +26,32 @@ type GetOptions struct {
 	IO         *iostreams.IOStreams
 	Config     func() (gh.Config, error)
 	BaseRepo   func() (ghrepo.Interface, error)
+	Exporter   cmdutil.Exporter
 
-	VariableName string
-	OrgName      string
-	EnvName      string
+	VariableNames     []string
+	FilePath          string
+	OrgName           string
+	EnvName           string
+	ShowSelectedRepos bool
 }
 
 type getVariableResponse struct {
-	Value string `json:"value"`
-	// Other available but unused fields
-	// Name             string            `json:"name"`
-	// UpdatedAt        time.Time         `json:"updated_at"`
-	// Visibility       shared.Visibility `json:"visibility"`
-	// SelectedReposURL string            `json:"selected_repositories_url"`
-	// NumSelectedRepos int               `json:"num_selected_repos"`
+	Name                    string            `json:"name"`
+	Value                   string            `json:"value"`
+	CreatedAt               time.Time         `json:"created_at"`
+	UpdatedAt               time.Time         `json:"updated_at"`
+	Visibility              shared.Visibility `json:"visibility"`
+	SelectedRepositoriesURL string            `json:"selected_repositories_url"`
+	NumSelectedRepos        int               `json:"num_selected_repos"`
+	SelectedRepositories    []string          `json:"selectedRepositories,omitempty"`
+}
+
+func (v *getVariableResponse) ExportData(fields []string) map[string]interface{} {
+	return cmdutil.StructExportData(v, fields)
+}
+
+type getVariablesResponse struct {
+	Variables []getVariableResponse `json:"variables"`
 }
 
 func NewCmdGet(f *cmdutil.Factory, runF func(*GetOptions) error) *cobra.Command {
@@ -44,15 +62,19 @@ func NewCmdGet(f *cmdutil.Factory, runF func(*GetOptions) error) *cobra.Command
 	}
 
 	cmd := &cobra.Command{
-		Use:   "get <variable-name>",
+		Use:   "get [<variable-name> ...]",
 		Short: "Get variables",
 		Long: heredoc.Doc(`
-			Get a variable on one of the following levels:
+			Get one or more variables on one of the following levels:
 			- repository (default): available to GitHub Actions runs or Dependabot in a repository
 			- environment: available to GitHub Actions runs for a deployment environment in a repository
 			- organization: available to GitHub Actions runs or Dependabot within an organization
+
+			Variable names can be passed as positional arguments, read from a file with
+			--file, or both. Use "-" as the --file argument to read names from standard
+			input.
 		`),
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MinimumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// support `-R, --repo` override
 			opts.BaseRepo = f.BaseRepo
@@ -61,7 +83,14 @@ func NewCmdGet(f *cmdutil.Factory, runF func(*GetOptions) error) *cobra.Command
 				return err
 			}
 
-			opts.VariableName = args[0]
+			names, err := collectVariableNames(opts.IO, args, opts.FilePath)
+			if err != nil {
+				return err
+			}
+			if len(names) == 0 {
+				return cmdutil.FlagErrorf("must specify at least one variable name or `--file`")
+			}
+			opts.VariableNames = names
 
 			if runF != nil {
 				return runF(opts)
@@ -72,10 +101,60 @@ func NewCmdGet(f *cmdutil.Factory, runF func(*GetOptions) error) *cobra.Command
 	}
 	cmd.Flags().StringVarP(&opts.OrgName, "org", "o", "", "Get a variable for an organization")
 	cmd.Flags().StringVarP(&opts.EnvName, "env", "e", "", "Get a variable for an environment")
+	cmd.Flags().StringVarP(&opts.FilePath, "file", "F", "", "Load variable names from a file (use \"-\" to read from standard input)")
+	cmd.Flags().BoolVar(&opts.ShowSelectedRepos, "show-selected-repos", false, "Show the repositories an organization variable with `selected` visibility is shared with")
+
+	cmdutil.AddJSONFlags(cmd, &opts.Exporter, variableFields)
 
 	return cmd
 }
 
+var variableFields = []string{
+	"name",
+	"value",
+	"createdAt",
+	"updatedAt",
+	"visibility",
+	"selectedRepositoriesUrl",
+	"numSelectedRepos",
+	"selectedRepositories",
+}
+
+func collectVariableNames(ios *iostreams.IOStreams, args []string, filePath string) ([]string, error) {
+	names := append([]string{}, args...)
+
+	if filePath == "" {
+		return names, nil
+	}
+
+	var r io.Reader
+	if filePath == "-" {
+		r = ios.In
+		defer ios.In.Close()
+	} else {
+		f, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	return names, nil
+}
+
 func getRun(opts *GetOptions) error {
 	c, err := opts.HttpClient()
 	if err != nil {
@@ -99,14 +178,14 @@ func getRun(opts *GetOptions) error {
 		}
 	}
 
-	var path string
+	var listPath string
 	switch variableEntity {
 	case shared.Organization:
-		path = fmt.Sprintf("orgs/%s/actions/variables/%s", orgName, opts.VariableName)
+		listPath = fmt.Sprintf("orgs/%s/actions/variables", orgName)
 	case shared.Environment:
-		path = fmt.Sprintf("repos/%s/environments/%s/variables/%s", ghrepo.FullName(baseRepo), envName, opts.VariableName)
+		listPath = fmt.Sprintf("repos/%s/environments/%s/variables", ghrepo.FullName(baseRepo), envName)
 	case shared.Repository:
-		path = fmt.Sprintf("repos/%s/actions/variables/%s", ghrepo.FullName(baseRepo), opts.VariableName)
+		listPath = fmt.Sprintf("repos/%s/actions/variables", ghrepo.FullName(baseRepo))
 	}
 
 	cfg, err := opts.Config()
@@ -116,17 +195,142 @@ func getRun(opts *GetOptions) error {
 
 	host, _ := cfg.Authentication().DefaultHost()
 
-	var response getVariableResponse
-	if err = client.REST(host, "GET", path, nil, &response); err != nil {
+	variables, err := getVariables(client, host, listPath)
+	if err != nil {
 		var httpErr api.HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
-			return fmt.Errorf("variable %s was not found", opts.VariableName)
+		if errors.As(err, &httpErr) {
+			if httpErr.StatusCode == http.StatusNotFound {
+				return fmt.Errorf("could not find any variables")
+			}
+
+			return shared.DecorateAPIError(fmt.Errorf("failed to get variables: %w", err), httpErr.Headers)
+		}
+
+		return fmt.Errorf("failed to get variables: %w", err)
+	}
+
+	byName := make(map[string]getVariableResponse, len(variables))
+	for _, v := range variables {
+		byName[v.Name] = v
+	}
+
+	found := make([]getVariableResponse, 0, len(opts.VariableNames))
+	var missing []string
+	for _, name := range opts.VariableNames {
+		v, ok := byName[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
 		}
+		found = append(found, v)
+	}
 
-		return fmt.Errorf("failed to get variable %s: %w", opts.VariableName, err)
+	if len(missing) > 0 {
+		if len(missing) > 1 {
+			return fmt.Errorf("variables %s were not found", strings.Join(missing, ", "))
+		}
+		return fmt.Errorf("variable %s was not found", missing[0])
 	}
 
-	fmt.Fprintf(opts.IO.Out, "%s\n", response.Value)
+	if opts.ShowSelectedRepos && variableEntity == shared.Organization {
+		for i := range found {
+			if found[i].Visibility != shared.Selected {
+				continue
+			}
+
+			repos, err := getSelectedRepositories(client, host, orgName, found[i].Name)
+			if err != nil {
+				wrapped := fmt.Errorf("failed to get selected repositories for variable %s: %w", found[i].Name, err)
+				var httpErr api.HTTPError
+				if errors.As(err, &httpErr) {
+					return shared.DecorateAPIError(wrapped, httpErr.Headers)
+				}
+				return wrapped
+			}
+			found[i].SelectedRepositories = repos
+		}
+	}
+
+	if opts.Exporter != nil {
+		if len(found) == 1 {
+			return opts.Exporter.Write(opts.IO, &found[0])
+		}
+		return opts.Exporter.Write(opts.IO, found)
+	}
+
+	if len(found) == 1 {
+		if opts.IO.IsStdoutTTY() {
+			fmt.Fprintf(opts.IO.Out, "%s\n", found[0].Value)
+			if len(found[0].SelectedRepositories) > 0 {
+				fmt.Fprintln(opts.IO.Out, "Selected repositories:")
+				for _, repo := range found[0].SelectedRepositories {
+					fmt.Fprintf(opts.IO.Out, "  %s\n", repo)
+				}
+			}
+		} else if opts.ShowSelectedRepos {
+			fmt.Fprintf(opts.IO.Out, "%s\t%s\n", found[0].Value, strings.Join(found[0].SelectedRepositories, ","))
+		} else {
+			fmt.Fprintf(opts.IO.Out, "%s\n", found[0].Value)
+		}
+		return nil
+	}
+
+	cs := opts.IO.ColorScheme()
+	tp := tableprinter.New(opts.IO)
+	for _, v := range found {
+		tp.AddField(v.Name)
+		tp.AddField(v.Value)
+		tp.AddTimeField(time.Now(), v.UpdatedAt, cs.Muted)
+		if opts.ShowSelectedRepos {
+			tp.AddField(strings.Join(v.SelectedRepositories, ","))
+		}
+		tp.EndRow()
+	}
+
+	return tp.Render()
+}
+
+// getSelectedRepositories fetches the repositories an organization variable
+// with `selected` visibility is shared with, following pagination.
+func getSelectedRepositories(client *api.Client, host, orgName, variableName string) ([]string, error) {
+	var repoNames []string
+
+	url := fmt.Sprintf("orgs/%s/actions/variables/%s/repositories?per_page=100", orgName, variableName)
+	for url != "" {
+		var response struct {
+			Repositories []struct {
+				FullName string `json:"full_name"`
+			} `json:"repositories"`
+		}
+		var err error
+		url, err = client.RESTWithNext(host, "GET", url, nil, &response)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range response.Repositories {
+			repoNames = append(repoNames, repo.FullName)
+		}
+	}
+
+	return repoNames, nil
+}
+
+// getVariables fetches every variable at listPath, following pagination, so that
+// multiple names can be resolved with a single round trip per page instead of
+// one request per requested name.
+func getVariables(client *api.Client, host, listPath string) ([]getVariableResponse, error) {
+	var variables []getVariableResponse
+
+	url := fmt.Sprintf("%s?per_page=100", listPath)
+	for url != "" {
+		var response getVariablesResponse
+		var err error
+		url, err = client.RESTWithNext(host, "GET", url, nil, &response)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, response.Variables...)
+	}
 
-	return nil
+	return variables, nil
 }
@@ -3,12 +3,15 @@ package get
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/cli/cli/v2/internal/config"
 	"github.com/cli/cli/v2/internal/gh"
 	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/cli/cli/v2/pkg/cmd/variable/shared"
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/cli/cli/v2/pkg/httpmock"
 	"github.com/cli/cli/v2/pkg/iostreams"
@@ -17,10 +20,17 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func jsonExporter(fields ...string) cmdutil.Exporter {
+	exporter := cmdutil.NewJSONExporter()
+	exporter.SetFields(fields)
+	return exporter
+}
+
 func TestNewCmdGet(t *testing.T) {
 	tests := []struct {
 		name    string
 		cli     string
+		stdin   string
 		wants   GetOptions
 		wantErr error
 	}{
@@ -28,24 +38,39 @@ func TestNewCmdGet(t *testing.T) {
 			name: "repo",
 			cli:  "FOO",
 			wants: GetOptions{
-				OrgName:      "",
-				VariableName: "FOO",
+				OrgName:       "",
+				VariableNames: []string{"FOO"},
 			},
 		},
 		{
 			name: "org",
 			cli:  "-o TestOrg BAR",
 			wants: GetOptions{
-				OrgName:      "TestOrg",
-				VariableName: "BAR",
+				OrgName:       "TestOrg",
+				VariableNames: []string{"BAR"},
 			},
 		},
 		{
 			name: "env",
 			cli:  "-e Development BAZ",
 			wants: GetOptions{
-				EnvName:      "Development",
-				VariableName: "BAZ",
+				EnvName:       "Development",
+				VariableNames: []string{"BAZ"},
+			},
+		},
+		{
+			name: "multiple names",
+			cli:  "FOO BAR BAZ",
+			wants: GetOptions{
+				VariableNames: []string{"FOO", "BAR", "BAZ"},
+			},
+		},
+		{
+			name:  "names from stdin via --file -",
+			cli:   "--file -",
+			stdin: "FOO\nBAR\n\nBAZ\n",
+			wants: GetOptions{
+				VariableNames: []string{"FOO", "BAR", "BAZ"},
 			},
 		},
 		{
@@ -53,11 +78,17 @@ func TestNewCmdGet(t *testing.T) {
 			cli:     "-o TestOrg -e Development QUX",
 			wantErr: cmdutil.FlagErrorf("%s", "specify only one of `--org` or `--env`"),
 		},
+		{
+			name:    "no names given",
+			cli:     "",
+			wantErr: cmdutil.FlagErrorf("%s", "must specify at least one variable name or `--file`"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ios, _, _, _ := iostreams.Test()
+			ios, stdin, _, _ := iostreams.Test()
+			stdin.WriteString(tt.stdin)
 			f := &cmdutil.Factory{
 				IOStreams: ios,
 			}
@@ -71,7 +102,6 @@ func TestNewCmdGet(t *testing.T) {
 				return nil
 			})
 			cmd.SetArgs(argv)
-			cmd.SetIn(&bytes.Buffer{})
 			cmd.SetOut(&bytes.Buffer{})
 			cmd.SetErr(&bytes.Buffer{})
 
@@ -84,7 +114,7 @@ func TestNewCmdGet(t *testing.T) {
 
 			require.Equal(t, tt.wants.OrgName, gotOpts.OrgName)
 			require.Equal(t, tt.wants.EnvName, gotOpts.EnvName)
-			require.Equal(t, tt.wants.VariableName, gotOpts.VariableName)
+			require.Equal(t, tt.wants.VariableNames, gotOpts.VariableNames)
 		})
 	}
 }
@@ -100,12 +130,14 @@ func Test_getRun(t *testing.T) {
 		{
 			name: "getting repo variable",
 			opts: &GetOptions{
-				VariableName: "VARIABLE_ONE",
+				VariableNames: []string{"VARIABLE_ONE"},
 			},
 			httpStubs: func(reg *httpmock.Registry) {
-				reg.Register(httpmock.REST("GET", "repos/owner/repo/actions/variables/VARIABLE_ONE"),
-					httpmock.JSONResponse(getVariableResponse{
-						Value: "repo_var",
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/actions/variables"),
+					httpmock.JSONResponse(getVariablesResponse{
+						Variables: []getVariableResponse{
+							{Name: "VARIABLE_ONE", Value: "repo_var"},
+						},
 					}))
 			},
 			wantOut: "repo_var\n",
@@ -113,13 +145,15 @@ func Test_getRun(t *testing.T) {
 		{
 			name: "getting org variable",
 			opts: &GetOptions{
-				OrgName:      "TestOrg",
-				VariableName: "VARIABLE_ONE",
+				OrgName:       "TestOrg",
+				VariableNames: []string{"VARIABLE_ONE"},
 			},
 			httpStubs: func(reg *httpmock.Registry) {
-				reg.Register(httpmock.REST("GET", "orgs/TestOrg/actions/variables/VARIABLE_ONE"),
-					httpmock.JSONResponse(getVariableResponse{
-						Value: "org_var",
+				reg.Register(httpmock.REST("GET", "orgs/TestOrg/actions/variables"),
+					httpmock.JSONResponse(getVariablesResponse{
+						Variables: []getVariableResponse{
+							{Name: "VARIABLE_ONE", Value: "org_var"},
+						},
 					}))
 			},
 			wantOut: "org_var\n",
@@ -127,40 +161,57 @@ func Test_getRun(t *testing.T) {
 		{
 			name: "getting env variable",
 			opts: &GetOptions{
-				EnvName:      "Development",
-				VariableName: "VARIABLE_ONE",
+				EnvName:       "Development",
+				VariableNames: []string{"VARIABLE_ONE"},
 			},
 			httpStubs: func(reg *httpmock.Registry) {
-				reg.Register(httpmock.REST("GET", "repos/owner/repo/environments/Development/variables/VARIABLE_ONE"),
-					httpmock.JSONResponse(getVariableResponse{
-						Value: "env_var",
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/environments/Development/variables"),
+					httpmock.JSONResponse(getVariablesResponse{
+						Variables: []getVariableResponse{
+							{Name: "VARIABLE_ONE", Value: "env_var"},
+						},
 					}))
 			},
 			wantOut: "env_var\n",
 		},
 		{
-			name: "when the variable is not found, an error is returned",
+			name: "when a requested variable is not found, an error is returned",
 			opts: &GetOptions{
-				VariableName: "VARIABLE_ONE",
+				VariableNames: []string{"VARIABLE_ONE"},
 			},
 			httpStubs: func(reg *httpmock.Registry) {
-				reg.Register(httpmock.REST("GET", "repos/owner/repo/actions/variables/VARIABLE_ONE"),
-					httpmock.StatusStringResponse(404, "not found"),
-				)
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/actions/variables"),
+					httpmock.JSONResponse(getVariablesResponse{}))
 			},
 			wantErr: fmt.Errorf("variable VARIABLE_ONE was not found"),
 		},
 		{
-			name: "when getting any variable from API fails, the error is bubbled with context",
+			name: "getting repo variable with --json",
+			opts: &GetOptions{
+				VariableNames: []string{"VARIABLE_ONE"},
+				Exporter:      jsonExporter("name", "value"),
+			},
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/actions/variables"),
+					httpmock.JSONResponse(getVariablesResponse{
+						Variables: []getVariableResponse{
+							{Name: "VARIABLE_ONE", Value: "repo_var"},
+						},
+					}))
+			},
+			wantOut: "{\"name\":\"VARIABLE_ONE\",\"value\":\"repo_var\"}\n",
+		},
+		{
+			name: "when listing variables from the API fails, the error is bubbled with context",
 			opts: &GetOptions{
-				VariableName: "VARIABLE_ONE",
+				VariableNames: []string{"VARIABLE_ONE"},
 			},
 			httpStubs: func(reg *httpmock.Registry) {
-				reg.Register(httpmock.REST("GET", "repos/owner/repo/actions/variables/VARIABLE_ONE"),
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/actions/variables"),
 					httpmock.StatusStringResponse(400, "not found"),
 				)
 			},
-			wantErr: fmt.Errorf("failed to get variable VARIABLE_ONE: HTTP 400 (https://api.github.com/repos/owner/repo/actions/variables/VARIABLE_ONE)"),
+			wantErr: fmt.Errorf("failed to get variables: HTTP 400 (https://api.github.com/repos/owner/repo/actions/variables?per_page=100)"),
 		},
 	}
 
@@ -200,3 +251,170 @@ func Test_getRun(t *testing.T) {
 		t.Run(tt.name+" no-tty", runTest(false))
 	}
 }
+
+func Test_getRun_multipleVariables(t *testing.T) {
+	tests := []struct {
+		name string
+		tty  bool
+	}{
+		{name: "tty", tty: true},
+		{name: "no-tty", tty: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &httpmock.Registry{}
+			defer reg.Verify(t)
+
+			reg.Register(httpmock.REST("GET", "repos/owner/repo/actions/variables"),
+				httpmock.JSONResponse(getVariablesResponse{
+					Variables: []getVariableResponse{
+						{Name: "VARIABLE_ONE", Value: "one"},
+						{Name: "VARIABLE_TWO", Value: "two"},
+					},
+				}))
+
+			ios, _, stdout, _ := iostreams.Test()
+			ios.SetStdoutTTY(tt.tty)
+
+			opts := &GetOptions{
+				VariableNames: []string{"VARIABLE_ONE", "VARIABLE_TWO"},
+				IO:            ios,
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.FromFullName("owner/repo")
+				},
+				HttpClient: func() (*http.Client, error) {
+					return &http.Client{Transport: reg}, nil
+				},
+				Config: func() (gh.Config, error) {
+					return config.NewBlankConfig(), nil
+				},
+			}
+
+			err := getRun(opts)
+			require.NoError(t, err)
+
+			out := stdout.String()
+			if tt.tty {
+				// The tty tableprinter pads/aligns columns rather than joining
+				// fields with a literal tab, so only assert on content here.
+				require.Contains(t, out, "VARIABLE_ONE")
+				require.Contains(t, out, "one")
+				require.Contains(t, out, "VARIABLE_TWO")
+				require.Contains(t, out, "two")
+			} else {
+				require.Equal(t, "VARIABLE_ONE\tone\t0001-01-01T00:00:00Z\nVARIABLE_TWO\ttwo\t0001-01-01T00:00:00Z\n", out)
+			}
+		})
+	}
+}
+
+func Test_getRun_showSelectedRepos(t *testing.T) {
+	tests := []struct {
+		name    string
+		tty     bool
+		wantOut string
+	}{
+		{
+			name:    "tty",
+			tty:     true,
+			wantOut: "org_var\nSelected repositories:\n  TestOrg/one\n  TestOrg/two\n",
+		},
+		{
+			name:    "no-tty",
+			tty:     false,
+			wantOut: "org_var\tTestOrg/one,TestOrg/two\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &httpmock.Registry{}
+			defer reg.Verify(t)
+
+			reg.Register(httpmock.REST("GET", "orgs/TestOrg/actions/variables"),
+				httpmock.JSONResponse(getVariablesResponse{
+					Variables: []getVariableResponse{
+						{Name: "VARIABLE_ONE", Value: "org_var", Visibility: shared.Selected},
+					},
+				}))
+			reg.Register(httpmock.REST("GET", "orgs/TestOrg/actions/variables/VARIABLE_ONE/repositories"),
+				httpmock.JSONResponse(map[string]interface{}{
+					"repositories": []map[string]string{
+						{"full_name": "TestOrg/one"},
+						{"full_name": "TestOrg/two"},
+					},
+				}))
+
+			ios, _, stdout, _ := iostreams.Test()
+			ios.SetStdoutTTY(tt.tty)
+
+			opts := &GetOptions{
+				OrgName:           "TestOrg",
+				VariableNames:     []string{"VARIABLE_ONE"},
+				ShowSelectedRepos: true,
+				IO:                ios,
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.FromFullName("owner/repo")
+				},
+				HttpClient: func() (*http.Client, error) {
+					return &http.Client{Transport: reg}, nil
+				},
+				Config: func() (gh.Config, error) {
+					return config.NewBlankConfig(), nil
+				},
+			}
+
+			err := getRun(opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOut, stdout.String())
+		})
+	}
+}
+
+func Test_getRun_showSelectedReposRateLimited(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	reg.Register(httpmock.REST("GET", "orgs/TestOrg/actions/variables"),
+		httpmock.JSONResponse(getVariablesResponse{
+			Variables: []getVariableResponse{
+				{Name: "VARIABLE_ONE", Value: "org_var", Visibility: shared.Selected},
+			},
+		}))
+	reg.Register(httpmock.REST("GET", "orgs/TestOrg/actions/variables/VARIABLE_ONE/repositories"),
+		func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Request:    req,
+				Header: http.Header{
+					"X-Ratelimit-Remaining": []string{"0"},
+					"X-Ratelimit-Reset":     []string{"1767225600"},
+				},
+				Body: io.NopCloser(strings.NewReader(`{"message":"rate limit exceeded"}`)),
+			}, nil
+		})
+
+	ios, _, _, _ := iostreams.Test()
+
+	opts := &GetOptions{
+		OrgName:           "TestOrg",
+		VariableNames:     []string{"VARIABLE_ONE"},
+		ShowSelectedRepos: true,
+		IO:                ios,
+		BaseRepo: func() (ghrepo.Interface, error) {
+			return ghrepo.FromFullName("owner/repo")
+		},
+		HttpClient: func() (*http.Client, error) {
+			return &http.Client{Transport: reg}, nil
+		},
+		Config: func() (gh.Config, error) {
+			return config.NewBlankConfig(), nil
+		},
+	}
+
+	err := getRun(opts)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to get selected repositories for variable VARIABLE_ONE")
+	require.Contains(t, err.Error(), "0 API requests remaining")
+}
@@ -0,0 +1,117 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type VariableEntity int
+
+const (
+	Repository VariableEntity = iota
+	Organization
+	Environment
+)
+
+type Visibility string
+
+const (
+	All      Visibility = "all"
+	Private  Visibility = "private"
+	Selected Visibility = "selected"
+)
+
+func GetVariableEntity(orgName, envName string) (VariableEntity, error) {
+	orgSet := orgName != ""
+	envSet := envName != ""
+
+	if orgSet && envSet {
+		return 0, errors.New("cannot specify both org and env")
+	}
+
+	switch {
+	case orgSet:
+		return Organization, nil
+	case envSet:
+		return Environment, nil
+	default:
+		return Repository, nil
+	}
+}
+
+// tokenExpirationHeader is the header GitHub returns on authenticated requests
+// to indicate when the current token will stop working.
+const tokenExpirationHeader = "GitHub-Authentication-Token-Expiration"
+
+// tokenExpirationFormats covers the two formats GitHub has been observed to send:
+// a named timezone ("MST") and a numeric UTC offset.
+var tokenExpirationFormats = []string{
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+}
+
+// ParseTokenExpiration parses the GitHub-Authentication-Token-Expiration header,
+// tolerating both formats GitHub sends. A missing or unparseable header, or a
+// zero Time, means "no expiration" and should be treated as such by callers.
+func ParseTokenExpiration(headers http.Header) time.Time {
+	header := headers.Get(tokenExpirationHeader)
+	if header == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range tokenExpirationFormats {
+		if t, err := time.Parse(layout, header); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// RateLimitFromHeaders extracts the remaining request count and the time the
+// current rate-limit window resets from the X-RateLimit-Remaining and
+// X-RateLimit-Reset headers. ok is false if either header is absent or malformed.
+func RateLimitFromHeaders(headers http.Header) (remaining int, resetAt time.Time, ok bool) {
+	remainingHeader := headers.Get("X-RateLimit-Remaining")
+	resetHeader := headers.Get("X-RateLimit-Reset")
+	if remainingHeader == "" || resetHeader == "" {
+		return 0, time.Time{}, false
+	}
+
+	remaining, err := strconv.Atoi(remainingHeader)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+
+	resetUnix, err := strconv.ParseInt(resetHeader, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+
+	return remaining, time.Unix(resetUnix, 0), true
+}
+
+// DecorateAPIError appends any token expiration and rate-limit context found in
+// headers to err, so that a "variable not found" error can be told apart from a
+// token that has expired or a rate limit that has been hit.
+func DecorateAPIError(err error, headers http.Header) error {
+	var details []string
+
+	if expiresAt := ParseTokenExpiration(headers); !expiresAt.IsZero() {
+		details = append(details, fmt.Sprintf("token expires %s", expiresAt.Local().Format(time.RFC1123)))
+	}
+
+	if remaining, resetAt, ok := RateLimitFromHeaders(headers); ok {
+		details = append(details, fmt.Sprintf("%d API requests remaining, resetting at %s", remaining, resetAt.Local().Format(time.RFC1123)))
+	}
+
+	if len(details) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%w (%s)", err, strings.Join(details, "; "))
+}
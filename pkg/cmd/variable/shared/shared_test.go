@@ -0,0 +1,83 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTokenExpiration(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   time.Time
+	}{
+		{
+			name:   "no header",
+			header: "",
+			want:   time.Time{},
+		},
+		{
+			name:   "named timezone",
+			header: "2026-01-02 15:04:05 UTC",
+			want:   time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:   "numeric offset",
+			header: "2026-01-02 15:04:05 -0700",
+			want:   time.Date(2026, 1, 2, 15, 4, 5, 0, time.FixedZone("", -7*60*60)),
+		},
+		{
+			name:   "unparseable",
+			header: "not a date",
+			want:   time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			if tt.header != "" {
+				headers.Set(tokenExpirationHeader, tt.header)
+			}
+			got := ParseTokenExpiration(headers)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestRateLimitFromHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "42")
+	headers.Set("X-RateLimit-Reset", "1767225600")
+
+	remaining, resetAt, ok := RateLimitFromHeaders(headers)
+	assert.True(t, ok)
+	assert.Equal(t, 42, remaining)
+	assert.Equal(t, int64(1767225600), resetAt.Unix())
+
+	_, _, ok = RateLimitFromHeaders(http.Header{})
+	assert.False(t, ok)
+}
+
+func TestDecorateAPIError(t *testing.T) {
+	baseErr := errors.New("failed to get variables")
+
+	t.Run("no relevant headers", func(t *testing.T) {
+		got := DecorateAPIError(baseErr, http.Header{})
+		assert.Equal(t, baseErr.Error(), got.Error())
+	})
+
+	t.Run("adds rate limit context", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("X-RateLimit-Remaining", "0")
+		headers.Set("X-RateLimit-Reset", "1767225600")
+
+		got := DecorateAPIError(baseErr, headers)
+		assert.Contains(t, got.Error(), "0 API requests remaining")
+		assert.True(t, errors.Is(got, baseErr))
+	})
+}